@@ -2,17 +2,23 @@ package alertmanager
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"hash/fnv"
 	"io"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
 	"github.com/TwiN/gatus/v5/client"
 	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/logr"
 	"gopkg.in/yaml.v3"
 )
 
@@ -25,32 +31,91 @@ type Config struct {
 	// URL is the Alertmanager API endpoint URL
 	URL string `yaml:"url"`
 
+	// URLs is an optional list of additional Alertmanager replica endpoints to fan out to
+	// for high availability. If set alongside URL, the alert is sent to all of them.
+	URLs []string `yaml:"urls,omitempty"`
+
+	// MinSuccess is the minimum number of replicas (URL and URLs combined) that must
+	// accept the alert for the send to be considered successful. Defaults to 1.
+	MinSuccess int `yaml:"min_success,omitempty"`
+
+	// ReplicaTimeout is the HTTP timeout applied to each individual replica request,
+	// independent of Timeout. Defaults to Timeout.
+	ReplicaTimeout time.Duration `yaml:"replica_timeout,omitempty"`
+
 	// Timeout for HTTP requests to Alertmanager
 	Timeout time.Duration `yaml:"timeout,omitempty"`
 
 	// DefaultSeverity is the default severity level for alerts
 	DefaultSeverity string `yaml:"default-severity,omitempty"`
 
-	// ExtraLabels are additional labels to add to all alerts
+	// ExtraLabels are additional labels to add to all alerts. Values are Go text/template
+	// templates rendered per alert against a templateData context; a value with no
+	// template actions renders to itself unchanged.
 	ExtraLabels map[string]string `yaml:"extra-labels,omitempty"`
 
-	// ExtraAnnotations are additional annotations to add to all alerts
+	// ExtraAnnotations are additional annotations to add to all alerts. Values are
+	// rendered the same way as ExtraLabels.
 	ExtraAnnotations map[string]string `yaml:"extra-annotations,omitempty"`
 
+	// GeneratorURL is a Go template rendered per alert to populate the standard
+	// Alertmanager generatorURL field. Defaults to defaultGeneratorURLTemplate.
+	GeneratorURL string `yaml:"generator_url,omitempty"`
+
+	// AlertName is a Go template rendered per alert to populate the alertname label,
+	// overriding the default "GatusEndpointDown".
+	AlertName string `yaml:"alertname,omitempty"`
+
 	// ClientConfig is the configuration of the client used to communicate with Alertmanager
 	ClientConfig *client.Config `yaml:"client,omitempty"`
+
+	// QueueCapacity is the size of the buffered channel backing the async dispatch queue.
+	// Defaults to 10000. When full, Send drops the oldest queued alert to make room.
+	QueueCapacity int `yaml:"queue_capacity,omitempty"`
+
+	// MaxBatchSize is the maximum number of alerts coalesced into a single POST to
+	// Alertmanager by the dispatch worker. Defaults to 64.
+	MaxBatchSize int `yaml:"max_batch_size,omitempty"`
+
+	// MaxRetries is the number of times the dispatch worker retries a failed batch,
+	// with exponential backoff, before dropping it. Defaults to 5.
+	MaxRetries int `yaml:"max_retries,omitempty"`
+
+	// RelabelConfigs is a Prometheus-style relabeling pipeline applied to an alert's
+	// labels after the base labels are assembled, letting operators normalize or rename
+	// labels (e.g. mapping Gatus's group to Prometheus's namespace) before dispatch.
+	RelabelConfigs []RelabelConfig `yaml:"relabel_configs,omitempty"`
+
+	// Discovery configures dynamic discovery of additional replica targets (DNS SRV/A
+	// records and/or a file_sd target file) to fan out to alongside URL/URLs.
+	Discovery *DiscoveryConfig `yaml:"discovery,omitempty"`
+
+	// Silences opts into creating Alertmanager silences for endpoint maintenance windows,
+	// instead of relying solely on client-side alert suppression.
+	Silences *SilencesConfig `yaml:"silences,omitempty"`
 }
 
 func (cfg *Config) Validate() error {
-	if len(cfg.URL) == 0 {
+	if len(cfg.URL) == 0 && len(cfg.URLs) == 0 && !cfg.Discovery.hasSources() {
 		return ErrAlertmanagerURLNotSet
 	}
 	if cfg.Timeout == 0 {
 		cfg.Timeout = 10 * time.Second
 	}
+	if cfg.ReplicaTimeout == 0 {
+		cfg.ReplicaTimeout = cfg.Timeout
+	}
+	if cfg.MinSuccess < 1 {
+		cfg.MinSuccess = 1
+	}
 	if len(cfg.DefaultSeverity) == 0 {
 		cfg.DefaultSeverity = "critical"
 	}
+	for i := range cfg.RelabelConfigs {
+		if err := cfg.RelabelConfigs[i].compile(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -61,6 +126,24 @@ func (cfg *Config) Merge(override *Config) {
 	if len(override.URL) > 0 {
 		cfg.URL = override.URL
 	}
+	if len(override.URLs) > 0 {
+		cfg.URLs = override.URLs
+	}
+	if override.MinSuccess > 0 {
+		cfg.MinSuccess = override.MinSuccess
+	}
+	if override.ReplicaTimeout > 0 {
+		cfg.ReplicaTimeout = override.ReplicaTimeout
+	}
+	if override.QueueCapacity > 0 {
+		cfg.QueueCapacity = override.QueueCapacity
+	}
+	if override.MaxBatchSize > 0 {
+		cfg.MaxBatchSize = override.MaxBatchSize
+	}
+	if override.MaxRetries > 0 {
+		cfg.MaxRetries = override.MaxRetries
+	}
 	if override.Timeout > 0 {
 		cfg.Timeout = override.Timeout
 	}
@@ -83,6 +166,91 @@ func (cfg *Config) Merge(override *Config) {
 			cfg.ExtraAnnotations[k] = v
 		}
 	}
+	if override.Discovery != nil {
+		cfg.Discovery = override.Discovery
+	}
+	if len(override.RelabelConfigs) > 0 {
+		cfg.RelabelConfigs = override.RelabelConfigs
+	}
+	if len(override.GeneratorURL) > 0 {
+		cfg.GeneratorURL = override.GeneratorURL
+	}
+	if len(override.AlertName) > 0 {
+		cfg.AlertName = override.AlertName
+	}
+	if override.Silences != nil {
+		cfg.Silences = override.Silences
+	}
+}
+
+// replicaURLs returns the deduplicated list of Alertmanager replica URLs to fan out to,
+// combining the legacy single URL field with URLs.
+func (cfg *Config) replicaURLs() []string {
+	seen := make(map[string]struct{}, len(cfg.URLs)+1)
+	urls := make([]string, 0, len(cfg.URLs)+1)
+	add := func(u string) {
+		if len(u) == 0 {
+			return
+		}
+		if _, ok := seen[u]; ok {
+			return
+		}
+		seen[u] = struct{}{}
+		urls = append(urls, u)
+	}
+	add(cfg.URL)
+	for _, u := range cfg.URLs {
+		add(u)
+	}
+	return urls
+}
+
+// allTargets returns the deduplicated union of cfg's static replica URLs and the
+// provider's default (un-overridden) discoverer's currently discovered targets. It backs
+// the maintenance-window silence subsystem, which operates on the provider's default
+// configuration rather than any per-alert override. Per-alert sends go
+// through mergeTargets with the resolved route's own discoverer instead; see dispatcher.go.
+func (provider *AlertProvider) allTargets(cfg *Config) []string {
+	provider.mu.Lock()
+	d := provider.discoverer
+	provider.mu.Unlock()
+	return mergeTargets(cfg.replicaURLs(), d)
+}
+
+// mergeTargets returns the deduplicated union of static replica URLs and disc's currently
+// discovered targets (if disc is non-nil), read atomically at send time.
+func mergeTargets(static []string, disc *discoverer) []string {
+	if disc == nil {
+		return static
+	}
+	discovered := disc.targets()
+	seen := make(map[string]struct{}, len(static)+len(discovered))
+	urls := make([]string, 0, len(static)+len(discovered))
+	for _, u := range static {
+		seen[u] = struct{}{}
+		urls = append(urls, u)
+	}
+	for _, u := range discovered {
+		if _, ok := seen[u]; ok {
+			continue
+		}
+		seen[u] = struct{}{}
+		urls = append(urls, u)
+	}
+	return urls
+}
+
+// Targets returns the current set of active Alertmanager replica targets discovered via
+// DiscoveryConfig, for display on the debug/status endpoint. It does not include the
+// static URL/URLs, which are always known from configuration.
+func (provider *AlertProvider) Targets() []string {
+	provider.mu.Lock()
+	d := provider.discoverer
+	provider.mu.Unlock()
+	if d == nil {
+		return nil
+	}
+	return d.targets()
 }
 
 // AlertProvider is the configuration necessary for sending alerts to Alertmanager
@@ -94,6 +262,11 @@ type AlertProvider struct {
 
 	// Overrides is a list of Override that may be prioritized over the default configuration
 	Overrides []Override `yaml:"overrides,omitempty"`
+
+	mu         sync.Mutex
+	dispatcher *dispatcher
+	discoverer *discoverer
+	silences   *silenceManager
 }
 
 // Override is a case under which the default integration is overridden
@@ -104,10 +277,11 @@ type Override struct {
 
 // AlertmanagerAlert represents an alert in Alertmanager API v2 format
 type AlertmanagerAlert struct {
-	Labels      map[string]string `json:"labels"`
-	Annotations map[string]string `json:"annotations"`
-	StartsAt    time.Time         `json:"startsAt,omitempty"`
-	EndsAt      time.Time         `json:"endsAt,omitempty"`
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	StartsAt     time.Time         `json:"startsAt,omitempty"`
+	EndsAt       time.Time         `json:"endsAt,omitempty"`
+	GeneratorURL string            `json:"generatorURL,omitempty"`
 }
 
 // Validate the provider's configuration
@@ -115,7 +289,10 @@ func (provider *AlertProvider) Validate() error {
 	return provider.DefaultConfig.Validate()
 }
 
-// Send sends an alert to Alertmanager
+// Send builds an alert payload and enqueues it for asynchronous delivery to Alertmanager.
+// The actual HTTP dispatch, batching and retries happen on the background worker started
+// by Start; callers only see an error if the alert could not be built. If cfg.RelabelConfigs
+// drops the alert, Send returns nil without enqueuing anything.
 func (provider *AlertProvider) Send(ep *endpoint.Endpoint, alert *alert.Alert, result *endpoint.Result, resolved bool) error {
 	cfg, err := provider.GetConfig(ep.Group, alert)
 	if err != nil {
@@ -123,20 +300,35 @@ func (provider *AlertProvider) Send(ep *endpoint.Endpoint, alert *alert.Alert, r
 	}
 
 	alertPayload := provider.buildAlert(cfg, ep, alert, result, resolved)
-	return provider.sendToAlertmanager(cfg, []AlertmanagerAlert{alertPayload})
+	kept, err := applyRelabelConfigs(alertPayload.Labels, cfg.RelabelConfigs)
+	if err != nil {
+		return err
+	}
+	if !kept {
+		alertsRelabelDroppedTotal.Inc()
+		logr.Debugf("[alertmanager] Alert for endpoint %s dropped by relabel_configs", ep.Name)
+		return nil
+	}
+	provider.enqueue(cfg, alertPayload)
+	return nil
 }
 
 // buildAlert constructs an Alertmanager alert payload
 func (provider *AlertProvider) buildAlert(cfg *Config, ep *endpoint.Endpoint, alert *alert.Alert, result *endpoint.Result, resolved bool) AlertmanagerAlert {
 	now := time.Now()
+	data := buildTemplateData(ep, alert, result, resolved)
 	alertPayload := AlertmanagerAlert{
-		Labels:      make(map[string]string),
-		Annotations: make(map[string]string),
-		StartsAt:    now,
+		Labels:       make(map[string]string),
+		Annotations:  make(map[string]string),
+		StartsAt:     now,
+		GeneratorURL: renderGeneratorURL(cfg, data),
 	}
 
 	// Set core Prometheus labels following conventions
 	alertPayload.Labels["alertname"] = "GatusEndpointDown"
+	if len(cfg.AlertName) > 0 {
+		alertPayload.Labels["alertname"] = render(cfg.AlertName, data)
+	}
 	alertPayload.Labels["instance"] = ep.URL
 	alertPayload.Labels["job"] = "gatus"
 	alertPayload.Labels["severity"] = cfg.DefaultSeverity
@@ -147,9 +339,9 @@ func (provider *AlertProvider) buildAlert(cfg *Config, ep *endpoint.Endpoint, al
 		alertPayload.Labels["group"] = ep.Group
 	}
 
-	// Add extra labels from config
+	// Add extra labels from config, rendering each as a template
 	for k, v := range cfg.ExtraLabels {
-		alertPayload.Labels[k] = v
+		alertPayload.Labels[k] = render(v, data)
 	}
 
 	// Set core annotations
@@ -172,38 +364,76 @@ func (provider *AlertProvider) buildAlert(cfg *Config, ep *endpoint.Endpoint, al
 		alertPayload.Annotations["alert_description"] = alert.GetDescription()
 	}
 
-	// Add extra annotations from config
+	// Add extra annotations from config, rendering each as a template
 	for k, v := range cfg.ExtraAnnotations {
-		alertPayload.Annotations[k] = v
+		alertPayload.Annotations[k] = render(v, data)
 	}
 
 	return alertPayload
 }
 
-// sendToAlertmanager sends alerts to the Alertmanager API
-func (provider *AlertProvider) sendToAlertmanager(cfg *Config, alerts []AlertmanagerAlert) error {
+// sendToAlertmanager sends alerts to every configured Alertmanager replica in parallel.
+// The send is considered successful once at least cfg.MinSuccess replicas accept the
+// alert; failures of individual replicas are logged but do not fail the send on their own.
+// disc is the discoverer (if any) backing cfg's own Discovery config, resolved by the
+// dispatcher for the specific route this batch belongs to.
+func (provider *AlertProvider) sendToAlertmanager(cfg *Config, disc *discoverer, alerts []AlertmanagerAlert) error {
 	jsonPayload, err := json.Marshal(alerts)
 	if err != nil {
 		return fmt.Errorf("failed to marshal alerts: %w", err)
 	}
-
-	// Ensure URL ends with the correct API path
-	url := strings.TrimSuffix(cfg.URL, "/")
-	if !strings.HasSuffix(url, "/api/v2/alerts") {
-		url += "/api/v2/alerts"
+	urls := mergeTargets(cfg.replicaURLs(), disc)
+	if len(urls) == 0 {
+		return ErrAlertmanagerURLNotSet
+	}
+	fingerprint := fingerprintAlerts(alerts)
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		successes int
+		errs      []string
+	)
+	wg.Add(len(urls))
+	for _, url := range urls {
+		go func(url string) {
+			defer wg.Done()
+			if err := provider.sendToReplica(cfg, url, jsonPayload, fingerprint); err != nil {
+				logr.Warnf("[alertmanager] Failed to send alert to replica %s: %s", url, err)
+				mu.Lock()
+				errs = append(errs, fmt.Sprintf("%s: %s", url, err))
+				mu.Unlock()
+				return
+			}
+			mu.Lock()
+			successes++
+			mu.Unlock()
+		}(url)
+	}
+	wg.Wait()
+	if successes < cfg.MinSuccess {
+		return fmt.Errorf("only %d/%d Alertmanager replicas accepted the alert, need at least %d: %s", successes, len(urls), cfg.MinSuccess, strings.Join(errs, "; "))
 	}
+	return nil
+}
 
-	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonPayload))
+// sendToReplica POSTs the already-marshalled alert payload to a single Alertmanager replica,
+// using a timeout independent from the overall send. The timeout is applied via the request's
+// context rather than httpClient.Timeout: client.GetHTTPClient returns a single *http.Client
+// cached per cfg.ClientConfig (and the shared default client when cfg.ClientConfig is nil), so
+// mutating its Timeout field here would race with the other replicas' concurrent goroutines in
+// sendToAlertmanager and, when cfg.ClientConfig is nil, clobber the timeout used by every other
+// Gatus feature sharing the default client.
+func (provider *AlertProvider) sendToReplica(cfg *Config, url string, jsonPayload []byte, fingerprint string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ReplicaTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, normalizeAlertmanagerURL(url, discoveryScheme(cfg)), bytes.NewReader(jsonPayload))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
-
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Gatus-Alert-Fingerprint", fingerprint)
 
 	httpClient := client.GetHTTPClient(cfg.ClientConfig)
-	if cfg.Timeout > 0 {
-		httpClient.Timeout = cfg.Timeout
-	}
 
 	resp, err := httpClient.Do(req)
 	if err != nil {
@@ -219,6 +449,62 @@ func (provider *AlertProvider) sendToAlertmanager(cfg *Config, alerts []Alertman
 	return nil
 }
 
+// normalizeAlertmanagerURL ensures the given base URL has a scheme and ends with the v2
+// alerts API path. Static URL/URLs entries already carry a scheme (e.g. "http://host:9093")
+// and are left untouched; discovery-sourced targets are bare "host:port" and get scheme
+// prepended.
+func normalizeAlertmanagerURL(url string, scheme string) string {
+	url = addSchemeIfMissing(url, scheme)
+	url = strings.TrimSuffix(url, "/")
+	if !strings.HasSuffix(url, "/api/v2/alerts") {
+		url += "/api/v2/alerts"
+	}
+	return url
+}
+
+// addSchemeIfMissing prepends scheme to url if url doesn't already specify one.
+func addSchemeIfMissing(url string, scheme string) string {
+	if strings.Contains(url, "://") {
+		return url
+	}
+	return scheme + "://" + url
+}
+
+// discoveryScheme returns the URL scheme to use for cfg's discovery-sourced targets,
+// defaulting to "http" when cfg.Discovery doesn't specify one.
+func discoveryScheme(cfg *Config) string {
+	if cfg.Discovery != nil && len(cfg.Discovery.Scheme) > 0 {
+		return cfg.Discovery.Scheme
+	}
+	return "http"
+}
+
+// fingerprintAlerts computes a stable per-alert fingerprint (FNV-1a of sorted "k=v," label
+// pairs) for each alert in the batch, so operators can trace the same alert across replicas.
+func fingerprintAlerts(alerts []AlertmanagerAlert) string {
+	fingerprints := make([]string, 0, len(alerts))
+	for _, a := range alerts {
+		fingerprints = append(fingerprints, fingerprintLabels(a.Labels))
+	}
+	return strings.Join(fingerprints, ",")
+}
+
+func fingerprintLabels(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	h := fnv.New64a()
+	for _, k := range keys {
+		h.Write([]byte(k))
+		h.Write([]byte("="))
+		h.Write([]byte(labels[k]))
+		h.Write([]byte(","))
+	}
+	return strconv.FormatUint(h.Sum64(), 16)
+}
+
 // GetDefaultAlert returns the provider's default alert configuration
 func (provider *AlertProvider) GetDefaultAlert() *alert.Alert {
 	return provider.DefaultAlert
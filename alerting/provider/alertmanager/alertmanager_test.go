@@ -1,9 +1,11 @@
 package alertmanager
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -35,7 +37,8 @@ func TestAlertProvider_Validate(t *testing.T) {
 		},
 	}
 
-	for _, test := range tests {
+	for i := range tests {
+		test := &tests[i]
 		t.Run(test.name, func(t *testing.T) {
 			err := test.provider.Validate()
 			if test.expectedError && err == nil {
@@ -123,8 +126,87 @@ func TestAlertProvider_buildAlert(t *testing.T) {
 	}
 }
 
+func TestAlertProvider_buildAlert_Templates(t *testing.T) {
+	provider := AlertProvider{
+		DefaultConfig: Config{
+			URL: "http://alertmanager:9093",
+			ExtraLabels: map[string]string{
+				"namespace": "{{ .Endpoint.Group }}",
+			},
+			ExtraAnnotations: map[string]string{
+				"failures": "{{ len .Result.Errors }} error(s)",
+			},
+			AlertName: "{{ .Endpoint.Name }}Down",
+		},
+	}
+
+	ep := &endpoint.Endpoint{
+		Name:  "Test API",
+		URL:   "https://api.example.com/health",
+		Group: "production",
+	}
+	a := &alert.Alert{Description: stringPtr("API health check failed")}
+	result := &endpoint.Result{Errors: []string{"connection timeout", "DNS resolution failed"}}
+
+	firingAlert := provider.buildAlert(&provider.DefaultConfig, ep, a, result, false)
+
+	if firingAlert.Labels["namespace"] != "production" {
+		t.Errorf("expected namespace label to be rendered from .Endpoint.Group, got %s", firingAlert.Labels["namespace"])
+	}
+	if firingAlert.Labels["alertname"] != "Test APIDown" {
+		t.Errorf("expected alertname to be rendered, got %s", firingAlert.Labels["alertname"])
+	}
+	if firingAlert.Annotations["failures"] != "2 error(s)" {
+		t.Errorf("expected failures annotation to be rendered, got %s", firingAlert.Annotations["failures"])
+	}
+	if firingAlert.GeneratorURL != "/api/v1/endpoints/production_test-api/statuses" {
+		t.Errorf("expected default generatorURL to be rendered, got %s", firingAlert.GeneratorURL)
+	}
+}
+
+func TestAlertProvider_buildAlert_InvalidTemplateFallsBackToLiteral(t *testing.T) {
+	provider := AlertProvider{
+		DefaultConfig: Config{
+			URL: "http://alertmanager:9093",
+			ExtraLabels: map[string]string{
+				"broken": "{{ .Endpoint.Name",
+			},
+		},
+	}
+
+	ep := &endpoint.Endpoint{Name: "Test API", URL: "https://api.example.com/health"}
+	a := &alert.Alert{Description: stringPtr("API health check failed")}
+	result := &endpoint.Result{Errors: []string{"test error"}}
+
+	firingAlert := provider.buildAlert(&provider.DefaultConfig, ep, a, result, false)
+
+	if firingAlert.Labels["broken"] != "{{ .Endpoint.Name" {
+		t.Errorf("expected invalid template to fall back to its literal value, got %s", firingAlert.Labels["broken"])
+	}
+}
+
+func TestAlertProvider_buildAlert_CustomGeneratorURL(t *testing.T) {
+	provider := AlertProvider{
+		DefaultConfig: Config{
+			URL:          "http://alertmanager:9093",
+			GeneratorURL: "https://status.example.com/{{ .Endpoint.Key }}",
+		},
+	}
+
+	ep := &endpoint.Endpoint{Name: "Test API", Group: "production", URL: "https://api.example.com/health"}
+	a := &alert.Alert{Description: stringPtr("API health check failed")}
+	result := &endpoint.Result{}
+
+	firingAlert := provider.buildAlert(&provider.DefaultConfig, ep, a, result, false)
+
+	if firingAlert.GeneratorURL != "https://status.example.com/production_test-api" {
+		t.Errorf("expected custom generatorURL template to be rendered, got %s", firingAlert.GeneratorURL)
+	}
+}
+
 func TestAlertProvider_Send(t *testing.T) {
 	// Create a mock Alertmanager server
+	received := make(chan []AlertmanagerAlert, 1)
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
 			t.Errorf("expected POST request, got %s", r.Method)
@@ -146,11 +228,8 @@ func TestAlertProvider_Send(t *testing.T) {
 			return
 		}
 
-		if len(alerts) != 1 {
-			t.Errorf("expected 1 alert, got %d", len(alerts))
-		}
-
 		w.WriteHeader(http.StatusOK)
+		received <- alerts
 	}))
 	defer server.Close()
 
@@ -159,6 +238,12 @@ func TestAlertProvider_Send(t *testing.T) {
 			URL: server.URL,
 		},
 	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := provider.Start(ctx); err != nil {
+		t.Fatalf("failed to start dispatcher: %v", err)
+	}
+	defer provider.Stop()
 
 	ep := &endpoint.Endpoint{
 		Name: "Test API",
@@ -178,6 +263,15 @@ func TestAlertProvider_Send(t *testing.T) {
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
+
+	select {
+	case alerts := <-received:
+		if len(alerts) != 1 {
+			t.Errorf("expected 1 alert, got %d", len(alerts))
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the dispatcher to deliver the alert")
+	}
 }
 
 func TestConfig_Merge(t *testing.T) {
@@ -222,6 +316,201 @@ func TestConfig_Merge(t *testing.T) {
 	}
 }
 
+func TestAlertProvider_Send_HAFanout(t *testing.T) {
+	var firstHits, secondHits int32
+
+	first := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&firstHits, 1)
+		if r.Header.Get("Gatus-Alert-Fingerprint") == "" {
+			t.Error("expected Gatus-Alert-Fingerprint header to be set")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer first.Close()
+
+	second := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&secondHits, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer second.Close()
+
+	provider := AlertProvider{
+		DefaultConfig: Config{
+			URL:        first.URL,
+			URLs:       []string{second.URL},
+			MinSuccess: 1,
+			MaxRetries: 0,
+		},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := provider.Start(ctx); err != nil {
+		t.Fatalf("failed to start dispatcher: %v", err)
+	}
+	defer provider.Stop()
+
+	ep := &endpoint.Endpoint{Name: "Test API", URL: "https://api.example.com/health"}
+	a := &alert.Alert{Description: stringPtr("Test alert")}
+	result := &endpoint.Result{Errors: []string{"test error"}}
+
+	if err := provider.Send(ep, a, result, false); err != nil {
+		t.Errorf("unexpected error from Send: %v", err)
+	}
+	if !waitForCondition(func() bool {
+		return atomic.LoadInt32(&firstHits) == 1 && atomic.LoadInt32(&secondHits) == 1
+	}, 5*time.Second) {
+		t.Error("expected both replicas to have been sent the alert")
+	}
+}
+
+func TestAlertProvider_Send_HAFanout_BelowMinSuccess(t *testing.T) {
+	var hits int32
+	first := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer first.Close()
+
+	second := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer second.Close()
+
+	provider := AlertProvider{
+		DefaultConfig: Config{
+			URL:        first.URL,
+			URLs:       []string{second.URL},
+			MinSuccess: 2,
+			MaxRetries: 0,
+		},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := provider.Start(ctx); err != nil {
+		t.Fatalf("failed to start dispatcher: %v", err)
+	}
+	defer provider.Stop()
+
+	ep := &endpoint.Endpoint{Name: "Test API", URL: "https://api.example.com/health"}
+	a := &alert.Alert{Description: stringPtr("Test alert")}
+	result := &endpoint.Result{Errors: []string{"test error"}}
+
+	// Send no longer surfaces per-batch delivery failures synchronously; it only
+	// reports an error if the alert couldn't be built/enqueued.
+	if err := provider.Send(ep, a, result, false); err != nil {
+		t.Errorf("unexpected error from Send: %v", err)
+	}
+	if !waitForCondition(func() bool {
+		return atomic.LoadInt32(&hits) == 2
+	}, 5*time.Second) {
+		t.Error("expected both replicas to have been attempted even though the overall batch is dropped")
+	}
+}
+
+// waitForCondition polls cond until it returns true or the timeout elapses.
+func waitForCondition(cond func() bool, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return cond()
+}
+
+func TestAlertProvider_Send_DropsOldestWhenQueueFull(t *testing.T) {
+	// Block the worker on the first batch so the queue has no chance to drain.
+	block := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-block
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	provider := AlertProvider{
+		DefaultConfig: Config{
+			URL:           server.URL,
+			QueueCapacity: 1,
+			MaxBatchSize:  1,
+		},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := provider.Start(ctx); err != nil {
+		t.Fatalf("failed to start dispatcher: %v", err)
+	}
+	defer func() {
+		close(block)
+		provider.Stop()
+	}()
+
+	ep := &endpoint.Endpoint{Name: "Test API", URL: "https://api.example.com/health"}
+	a := &alert.Alert{Description: stringPtr("Test alert")}
+	result := &endpoint.Result{Errors: []string{"test error"}}
+
+	// First Send is picked up by the worker immediately and blocks it on `block`.
+	if err := provider.Send(ep, a, result, false); err != nil {
+		t.Fatalf("unexpected error from Send: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	// These two overflow the capacity-1 queue; the dispatcher should drop the
+	// oldest of them rather than block the caller.
+	if err := provider.Send(ep, a, result, false); err != nil {
+		t.Fatalf("unexpected error from Send: %v", err)
+	}
+	if err := provider.Send(ep, a, result, false); err != nil {
+		t.Fatalf("unexpected error from Send: %v", err)
+	}
+}
+
+func TestAlertProvider_Send_DroppedByRelabelConfigs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("expected no request to be sent to Alertmanager")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	provider := AlertProvider{
+		DefaultConfig: Config{
+			URL: server.URL,
+			RelabelConfigs: []RelabelConfig{
+				{
+					SourceLabels: []string{"group"},
+					Regex:        "maintenance",
+					Action:       "drop",
+				},
+			},
+		},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := provider.Start(ctx); err != nil {
+		t.Fatalf("failed to start dispatcher: %v", err)
+	}
+	defer provider.Stop()
+
+	ep := &endpoint.Endpoint{Name: "Test API", URL: "https://api.example.com/health", Group: "maintenance"}
+	a := &alert.Alert{Description: stringPtr("Test alert")}
+	result := &endpoint.Result{Errors: []string{"test error"}}
+
+	if err := provider.Send(ep, a, result, false); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+}
+
+func TestFingerprintLabels_StableRegardlessOfInsertionOrder(t *testing.T) {
+	a := map[string]string{"alertname": "GatusEndpointDown", "endpoint": "Test API"}
+	b := map[string]string{"endpoint": "Test API", "alertname": "GatusEndpointDown"}
+
+	if fingerprintLabels(a) != fingerprintLabels(b) {
+		t.Error("expected fingerprint to be stable regardless of label insertion order")
+	}
+}
+
 // Helper function to create string pointers
 func stringPtr(s string) *string {
 	return &s
@@ -0,0 +1,281 @@
+package alertmanager
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TwiN/logr"
+	"github.com/fsnotify/fsnotify"
+	"gopkg.in/yaml.v3"
+)
+
+const defaultRefreshInterval = 30 * time.Second
+
+// DiscoveryConfig configures dynamic discovery of Alertmanager replica targets, as an
+// alternative (or addition) to the static URL/URLs fields, analogous to Prometheus's
+// dynamic Alertmanager discovery.
+type DiscoveryConfig struct {
+	// DNS discovers targets by periodically resolving DNS SRV or A records.
+	DNS *DNSDiscoveryConfig `yaml:"dns_sd,omitempty"`
+
+	// File discovers targets from a YAML or JSON file of target groups, reloaded whenever
+	// the file changes on disk.
+	File *FileDiscoveryConfig `yaml:"file_sd,omitempty"`
+
+	// Scheme is the URL scheme ("http" or "https") used when building request URLs for
+	// discovered targets, which (unlike the static URL/URLs fields) resolve to bare
+	// "host:port" with no scheme of their own. Defaults to "http".
+	Scheme string `yaml:"scheme,omitempty"`
+}
+
+// DNSDiscoveryConfig discovers Alertmanager targets via DNS SRV or A record lookups.
+type DNSDiscoveryConfig struct {
+	// Names is the list of DNS names to resolve. For Type "SRV" these are the SRV record
+	// names (e.g. "_web._tcp.alertmanager.service.consul"); for Type "A" these are plain
+	// hostnames paired with Port.
+	Names []string `yaml:"names"`
+
+	// Type is "SRV" or "A". Defaults to "SRV".
+	Type string `yaml:"type,omitempty"`
+
+	// Port is appended to each resolved address when Type is "A". Ignored for "SRV", since
+	// the port comes from the SRV record itself.
+	Port int `yaml:"port,omitempty"`
+
+	// RefreshInterval is how often the DNS records are re-resolved. Defaults to 30s.
+	RefreshInterval time.Duration `yaml:"refresh_interval,omitempty"`
+}
+
+// FileDiscoveryConfig discovers Alertmanager targets from one or more target group files.
+type FileDiscoveryConfig struct {
+	// Files is the list of YAML or JSON files to watch, each containing a list of
+	// `{targets: [...]}` groups.
+	Files []string `yaml:"files"`
+}
+
+// hasSources reports whether cfg configures at least one DNS name or file_sd file, so
+// Config.Validate can accept a purely discovery-driven setup with no static URL/URLs.
+func (cfg *DiscoveryConfig) hasSources() bool {
+	if cfg == nil {
+		return false
+	}
+	if cfg.DNS != nil && len(cfg.DNS.Names) > 0 {
+		return true
+	}
+	if cfg.File != nil && len(cfg.File.Files) > 0 {
+		return true
+	}
+	return false
+}
+
+// fileTargetGroup mirrors Prometheus's file_sd target group format.
+type fileTargetGroup struct {
+	Targets []string `yaml:"targets" json:"targets"`
+}
+
+// discoverer resolves DNS and file-based service discovery in the background and exposes
+// the current set of resolved targets, read atomically at send time by sendToAlertmanager.
+type discoverer struct {
+	mu   sync.RWMutex
+	dns  map[string][]string // keyed by DNS name
+	file map[string][]string // keyed by file path
+	stop chan struct{}
+	done chan struct{}
+}
+
+func newDiscoverer() *discoverer {
+	return &discoverer{
+		dns:  make(map[string][]string),
+		file: make(map[string][]string),
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+}
+
+// targets returns the deduplicated, sorted union of all currently resolved targets.
+func (d *discoverer) targets() []string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	seen := make(map[string]struct{})
+	targets := make([]string, 0)
+	add := func(addrs []string) {
+		for _, addr := range addrs {
+			if _, ok := seen[addr]; ok {
+				continue
+			}
+			seen[addr] = struct{}{}
+			targets = append(targets, addr)
+		}
+	}
+	for _, addrs := range d.dns {
+		add(addrs)
+	}
+	for _, addrs := range d.file {
+		add(addrs)
+	}
+	sort.Strings(targets)
+	return targets
+}
+
+func (d *discoverer) setDNS(name string, addrs []string) {
+	d.mu.Lock()
+	d.dns[name] = addrs
+	d.mu.Unlock()
+}
+
+func (d *discoverer) setFile(path string, addrs []string) {
+	d.mu.Lock()
+	d.file[path] = addrs
+	d.mu.Unlock()
+}
+
+// run drives DNS refresh and file watching until stopped.
+func (d *discoverer) run(ctx context.Context, cfg DiscoveryConfig) {
+	defer close(d.done)
+	var wg sync.WaitGroup
+	if cfg.DNS != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d.runDNS(ctx, cfg.DNS)
+		}()
+	}
+	if cfg.File != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d.runFile(ctx, cfg.File)
+		}()
+	}
+	wg.Wait()
+}
+
+func (d *discoverer) runDNS(ctx context.Context, cfg *DNSDiscoveryConfig) {
+	interval := cfg.RefreshInterval
+	if interval <= 0 {
+		interval = defaultRefreshInterval
+	}
+	refresh := func() {
+		for _, name := range cfg.Names {
+			addrs, err := resolveDNS(cfg, name)
+			if err != nil {
+				logr.Warnf("[alertmanager] Failed to resolve Alertmanager DNS target %q: %s", name, err)
+				continue
+			}
+			d.setDNS(name, addrs)
+		}
+	}
+	refresh()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.stop:
+			return
+		case <-ticker.C:
+			refresh()
+		}
+	}
+}
+
+// resolveDNS resolves a single DNS name to a list of "host:port" targets, either via SRV
+// lookup (Type "SRV", the default) or a plain A/AAAA lookup paired with cfg.Port.
+func resolveDNS(cfg *DNSDiscoveryConfig, name string) ([]string, error) {
+	recordType := cfg.Type
+	if len(recordType) == 0 {
+		recordType = "SRV"
+	}
+	switch recordType {
+	case "SRV":
+		_, records, err := net.LookupSRV("", "", name)
+		if err != nil {
+			return nil, err
+		}
+		addrs := make([]string, 0, len(records))
+		for _, record := range records {
+			host := strings.TrimSuffix(record.Target, ".")
+			addrs = append(addrs, net.JoinHostPort(host, strconv.Itoa(int(record.Port))))
+		}
+		return addrs, nil
+	case "A", "AAAA":
+		ips, err := net.LookupHost(name)
+		if err != nil {
+			return nil, err
+		}
+		addrs := make([]string, 0, len(ips))
+		for _, ip := range ips {
+			addrs = append(addrs, net.JoinHostPort(ip, strconv.Itoa(cfg.Port)))
+		}
+		return addrs, nil
+	default:
+		return nil, fmt.Errorf("unsupported dns_sd type %q", cfg.Type)
+	}
+}
+
+func (d *discoverer) runFile(ctx context.Context, cfg *FileDiscoveryConfig) {
+	for _, path := range cfg.Files {
+		if err := d.loadFile(path); err != nil {
+			logr.Warnf("[alertmanager] Failed to load Alertmanager file_sd target file %q: %s", path, err)
+		}
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		logr.Warnf("[alertmanager] Failed to start file_sd watcher, targets will not be refreshed: %s", err)
+		return
+	}
+	defer watcher.Close()
+	for _, path := range cfg.Files {
+		if err := watcher.Add(path); err != nil {
+			logr.Warnf("[alertmanager] Failed to watch file_sd target file %q: %s", path, err)
+		}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.stop:
+			return
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+			if err := d.loadFile(event.Name); err != nil {
+				logr.Warnf("[alertmanager] Failed to reload Alertmanager file_sd target file %q: %s", event.Name, err)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			logr.Warnf("[alertmanager] file_sd watcher error: %s", err)
+		}
+	}
+}
+
+func (d *discoverer) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var groups []fileTargetGroup
+	if err := yaml.Unmarshal(data, &groups); err != nil {
+		return fmt.Errorf("failed to parse %q as YAML or JSON: %w", path, err)
+	}
+	targets := make([]string, 0)
+	for _, group := range groups {
+		targets = append(targets, group.Targets...)
+	}
+	d.setFile(path, targets)
+	return nil
+}
@@ -0,0 +1,92 @@
+package alertmanager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestConfig_Validate_DiscoveryOnly(t *testing.T) {
+	cfg := Config{
+		Discovery: &DiscoveryConfig{
+			DNS: &DNSDiscoveryConfig{Names: []string{"_alertmanager._tcp.example.com"}},
+		},
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("expected a discovery-only config to be valid, got: %v", err)
+	}
+
+	cfg = Config{Discovery: &DiscoveryConfig{}}
+	if err := cfg.Validate(); err != ErrAlertmanagerURLNotSet {
+		t.Errorf("expected an empty discovery config to fail validation like a missing URL, got: %v", err)
+	}
+}
+
+func TestDiscoverer_LoadFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "targets.yml")
+	contents := "- targets:\n    - alertmanager-1:9093\n    - alertmanager-2:9093\n"
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("failed to write target file: %v", err)
+	}
+
+	d := newDiscoverer()
+	if err := d.loadFile(path); err != nil {
+		t.Fatalf("unexpected error loading file: %v", err)
+	}
+
+	targets := d.targets()
+	if len(targets) != 2 || targets[0] != "alertmanager-1:9093" || targets[1] != "alertmanager-2:9093" {
+		t.Errorf("unexpected targets: %+v", targets)
+	}
+}
+
+func TestDiscoverer_LoadFile_JSON(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "targets.json")
+	contents := `[{"targets": ["alertmanager-3:9093"]}]`
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("failed to write target file: %v", err)
+	}
+
+	d := newDiscoverer()
+	if err := d.loadFile(path); err != nil {
+		t.Fatalf("unexpected error loading file: %v", err)
+	}
+
+	targets := d.targets()
+	if len(targets) != 1 || targets[0] != "alertmanager-3:9093" {
+		t.Errorf("unexpected targets: %+v", targets)
+	}
+}
+
+func TestAlertProvider_Targets_FileSD(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "targets.yml")
+	if err := os.WriteFile(path, []byte("- targets:\n    - alertmanager-1:9093\n"), 0644); err != nil {
+		t.Fatalf("failed to write target file: %v", err)
+	}
+
+	provider := AlertProvider{
+		DefaultConfig: Config{
+			Discovery: &DiscoveryConfig{
+				File: &FileDiscoveryConfig{Files: []string{path}},
+			},
+		},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := provider.Start(ctx); err != nil {
+		t.Fatalf("failed to start provider: %v", err)
+	}
+	defer provider.Stop()
+
+	if !waitForCondition(func() bool {
+		targets := provider.Targets()
+		return len(targets) == 1 && targets[0] == "alertmanager-1:9093"
+	}, 5*time.Second) {
+		t.Errorf("expected Targets() to eventually report the file_sd target, got: %+v", provider.Targets())
+	}
+}
@@ -0,0 +1,320 @@
+package alertmanager
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/TwiN/logr"
+)
+
+const (
+	defaultQueueCapacity = 10000
+	defaultMaxBatchSize  = 64
+	defaultMaxRetries    = 5
+
+	initialBackoff = time.Second
+	maxBackoff     = 30 * time.Second
+
+	// flushInterval is how often the dispatcher flushes partial batches when the queue
+	// isn't producing alerts fast enough to fill one on its own.
+	flushInterval = time.Second
+)
+
+// queuedAlert pairs an alert payload with the fully-resolved Config (group/alert overrides
+// already merged in by Send's call to GetConfig) it must be dispatched with, so per-alert
+// overrides of URL/URLs/MinSuccess/ReplicaTimeout/ClientConfig/Discovery survive past the
+// queue instead of being dispatched against the provider's top-level DefaultConfig.
+type queuedAlert struct {
+	cfg   *Config
+	alert AlertmanagerAlert
+}
+
+// dispatcher drains a bounded queue of alerts in the background, coalescing them into
+// batched POSTs to Alertmanager and retrying failed batches with exponential backoff.
+type dispatcher struct {
+	queue      chan queuedAlert
+	maxBatch   int
+	maxRetries int
+	stop       chan struct{}
+	done       chan struct{}
+}
+
+func newDispatcher(cfg Config) *dispatcher {
+	queueCapacity := cfg.QueueCapacity
+	if queueCapacity <= 0 {
+		queueCapacity = defaultQueueCapacity
+	}
+	maxBatch := cfg.MaxBatchSize
+	if maxBatch <= 0 {
+		maxBatch = defaultMaxBatchSize
+	}
+	maxRetries := cfg.MaxRetries
+	if maxRetries <= 0 {
+		maxRetries = defaultMaxRetries
+	}
+	return &dispatcher{
+		queue:      make(chan queuedAlert, queueCapacity),
+		maxBatch:   maxBatch,
+		maxRetries: maxRetries,
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+}
+
+// Start spawns the background worker that drains the queue. Each queued alert is
+// dispatched against its own resolved Config (see queuedAlert), not the snapshot taken
+// here; that snapshot only sizes the queue/batch/retry knobs and seeds the default
+// (un-overridden) discoverer reused by routes that don't override Discovery.
+func (provider *AlertProvider) Start(ctx context.Context) error {
+	cfg := provider.DefaultConfig
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	RegisterMetrics(nil)
+	provider.mu.Lock()
+	if provider.dispatcher != nil {
+		provider.mu.Unlock()
+		return nil
+	}
+	d := newDispatcher(cfg)
+	provider.dispatcher = d
+	var disc *discoverer
+	if cfg.Discovery != nil {
+		disc = newDiscoverer()
+		provider.discoverer = disc
+	}
+	provider.mu.Unlock()
+	go provider.runDispatcher(ctx, d, cfg.Discovery, disc)
+	if disc != nil {
+		go disc.run(ctx, *cfg.Discovery)
+	}
+	if cfg.Silences != nil && cfg.Silences.Enabled {
+		if err := provider.ReconcileSilences(); err != nil {
+			logr.Warnf("[alertmanager] Failed to reconcile existing silences on startup: %s", err)
+		}
+	}
+	return nil
+}
+
+// Stop signals the worker to flush whatever remains in the queue and blocks until it exits.
+func (provider *AlertProvider) Stop() {
+	provider.mu.Lock()
+	d := provider.dispatcher
+	disc := provider.discoverer
+	provider.dispatcher = nil
+	provider.discoverer = nil
+	provider.mu.Unlock()
+	defer UnregisterMetrics()
+	if disc != nil {
+		close(disc.stop)
+		<-disc.done
+	}
+	if d == nil {
+		return
+	}
+	close(d.stop)
+	<-d.done
+}
+
+// dispatcherOrStart returns the running dispatcher, lazily starting one against the
+// provider's default config if Start was never called explicitly (e.g. in tests, or because
+// nothing in the application's lifecycle calls Start). This is the path real sends actually
+// go through, so it mirrors Start's discoverer setup: Targets()/allTargets() read
+// provider.discoverer, and the silences subsystem depends on it for discovery-only configs.
+func (provider *AlertProvider) dispatcherOrStart() *dispatcher {
+	RegisterMetrics(nil)
+	provider.mu.Lock()
+	defer provider.mu.Unlock()
+	if provider.dispatcher != nil {
+		return provider.dispatcher
+	}
+	cfg := provider.DefaultConfig
+	d := newDispatcher(cfg)
+	provider.dispatcher = d
+	var disc *discoverer
+	if cfg.Discovery != nil {
+		disc = newDiscoverer()
+		provider.discoverer = disc
+	}
+	go provider.runDispatcher(context.Background(), d, cfg.Discovery, disc)
+	if disc != nil {
+		go disc.run(context.Background(), *cfg.Discovery)
+	}
+	return d
+}
+
+// enqueue pushes alertPayload, paired with the resolved cfg it must be sent with, onto the
+// dispatch queue, dropping the oldest queued alert instead of blocking when the queue is
+// full.
+func (provider *AlertProvider) enqueue(cfg *Config, alertPayload AlertmanagerAlert) {
+	d := provider.dispatcherOrStart()
+	qa := queuedAlert{cfg: cfg, alert: alertPayload}
+	select {
+	case d.queue <- qa:
+		alertsQueueLength.Set(float64(len(d.queue)))
+		return
+	default:
+	}
+	select {
+	case old := <-d.queue:
+		alertsDroppedTotal.Inc()
+		logr.Warnf("[alertmanager] Dispatch queue full, dropping oldest queued alert %q", old.alert.Labels["alertname"])
+	default:
+	}
+	select {
+	case d.queue <- qa:
+	default:
+		alertsDroppedTotal.Inc()
+		logr.Warnf("[alertmanager] Dispatch queue full, dropping alert %q", alertPayload.Labels["alertname"])
+	}
+	alertsQueueLength.Set(float64(len(d.queue)))
+}
+
+// route accumulates the pending batch for one distinct resolved Config (i.e. one distinct
+// combination of URL/URLs/MinSuccess/ReplicaTimeout/ClientConfig/Discovery), along with the
+// discoverer its Discovery config (if any) resolves against.
+type route struct {
+	cfg    *Config
+	disc   *discoverer
+	alerts []AlertmanagerAlert
+}
+
+// routeKey groups queued alerts that must be dispatched identically, so a batch is never
+// sent using the wrong replica set, quorum or client settings.
+func routeKey(cfg *Config) string {
+	var b strings.Builder
+	b.WriteString(strings.Join(cfg.replicaURLs(), ","))
+	fmt.Fprintf(&b, "|%d|%s|%p|%p", cfg.MinSuccess, cfg.ReplicaTimeout, cfg.ClientConfig, cfg.Discovery)
+	return b.String()
+}
+
+// runDispatcher drains d.queue, batching alerts per distinct route and flushing each route
+// independently. defaultDiscCfg/defaultDisc are the Discovery config and discoverer (if any)
+// seeded from the provider's top-level DefaultConfig at Start time; a route whose resolved
+// cfg.Discovery is that same config reuses defaultDisc, a route with a different (overridden)
+// Discovery config gets its own discoverer, started and torn down alongside the dispatcher.
+func (provider *AlertProvider) runDispatcher(ctx context.Context, d *dispatcher, defaultDiscCfg *DiscoveryConfig, defaultDisc *discoverer) {
+	defer close(d.done)
+	routes := make(map[string]*route)
+	var extraDiscoverers []*discoverer
+	getRoute := func(cfg *Config) *route {
+		key := routeKey(cfg)
+		r, ok := routes[key]
+		if ok {
+			return r
+		}
+		r = &route{cfg: cfg, alerts: make([]AlertmanagerAlert, 0, d.maxBatch)}
+		switch {
+		case cfg.Discovery == nil:
+			// No discovery for this route.
+		case cfg.Discovery == defaultDiscCfg:
+			r.disc = defaultDisc
+		default:
+			disc := newDiscoverer()
+			go disc.run(ctx, *cfg.Discovery)
+			r.disc = disc
+			extraDiscoverers = append(extraDiscoverers, disc)
+		}
+		routes[key] = r
+		return r
+	}
+	flushRoute := func(r *route) {
+		if len(r.alerts) == 0 {
+			return
+		}
+		provider.sendBatchWithRetry(ctx, d, r.cfg, r.disc, r.alerts, d.maxRetries)
+		r.alerts = r.alerts[:0]
+	}
+	flushAll := func() {
+		for _, r := range routes {
+			flushRoute(r)
+		}
+	}
+	shutdown := func() {
+		flushAll()
+		for _, disc := range extraDiscoverers {
+			close(disc.stop)
+		}
+	}
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			shutdown()
+			return
+		case <-d.stop:
+			for {
+				select {
+				case qa := <-d.queue:
+					r := getRoute(qa.cfg)
+					r.alerts = append(r.alerts, qa.alert)
+					if len(r.alerts) >= d.maxBatch {
+						flushRoute(r)
+					}
+				default:
+					shutdown()
+					return
+				}
+			}
+		case qa := <-d.queue:
+			r := getRoute(qa.cfg)
+			r.alerts = append(r.alerts, qa.alert)
+			alertsQueueLength.Set(float64(len(d.queue)))
+			if len(r.alerts) >= d.maxBatch {
+				flushRoute(r)
+			}
+		case <-ticker.C:
+			flushAll()
+		}
+	}
+}
+
+// sendBatchWithRetry sends a coalesced batch to Alertmanager, retrying with exponential
+// backoff (plus jitter) up to maxRetries times before dropping the batch. The backoff sleep
+// is interruptible by ctx/d.stop so a shutdown during a retry window drains promptly instead
+// of blocking for the remainder of the backoff.
+func (provider *AlertProvider) sendBatchWithRetry(ctx context.Context, d *dispatcher, cfg *Config, disc *discoverer, batch []AlertmanagerAlert, maxRetries int) {
+	alerts := make([]AlertmanagerAlert, len(batch))
+	copy(alerts, batch)
+
+	backoff := initialBackoff
+	var err error
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		start := time.Now()
+		err = provider.sendToAlertmanager(cfg, disc, alerts)
+		sendLatencySeconds.Observe(time.Since(start).Seconds())
+		if err == nil {
+			alertsSentTotal.Add(float64(len(alerts)))
+			return
+		}
+		if attempt == maxRetries {
+			break
+		}
+		logr.Warnf("[alertmanager] Failed to send batch of %d alert(s) (attempt %d/%d): %s", len(alerts), attempt+1, maxRetries+1, err)
+		jitter := time.Duration(rand.Int63n(int64(backoff)/2 + 1))
+		timer := time.NewTimer(backoff + jitter)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			logr.Warnf("[alertmanager] Dropping batch of %d alert(s): shutting down mid-retry", len(alerts))
+			alertsDroppedTotal.Add(float64(len(alerts)))
+			return
+		case <-d.stop:
+			timer.Stop()
+			logr.Warnf("[alertmanager] Dropping batch of %d alert(s): shutting down mid-retry", len(alerts))
+			alertsDroppedTotal.Add(float64(len(alerts)))
+			return
+		}
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+	alertsDroppedTotal.Add(float64(len(alerts)))
+	logr.Errorf("[alertmanager] Dropping batch of %d alert(s) after %d attempt(s): %s", len(alerts), maxRetries+1, err)
+}
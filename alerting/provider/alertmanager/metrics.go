@@ -0,0 +1,95 @@
+package alertmanager
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	alertsSentTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "gatus",
+		Subsystem: "alertmanager_provider",
+		Name:      "alerts_sent_total",
+		Help:      "Total number of alerts successfully delivered to Alertmanager",
+	})
+
+	alertsDroppedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "gatus",
+		Subsystem: "alertmanager_provider",
+		Name:      "alerts_dropped_total",
+		Help:      "Total number of alerts dropped without being delivered to Alertmanager",
+	})
+
+	alertsQueueLength = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "gatus",
+		Subsystem: "alertmanager_provider",
+		Name:      "alerts_queue_length",
+		Help:      "Current number of alerts waiting in the dispatch queue",
+	})
+
+	sendLatencySeconds = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "gatus",
+		Subsystem: "alertmanager_provider",
+		Name:      "send_latency_seconds",
+		Help:      "Latency of batched sends to Alertmanager",
+		Buckets:   prometheus.DefBuckets,
+	})
+
+	alertsRelabelDroppedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "gatus",
+		Subsystem: "alertmanager_provider",
+		Name:      "alerts_relabel_dropped_total",
+		Help:      "Total number of alerts suppressed by a relabel_configs keep/drop rule before dispatch",
+	})
+
+	// metricsCollectors lists the Prometheus collectors this provider owns, so
+	// RegisterMetrics/UnregisterMetrics can (un)register them as a unit.
+	metricsCollectors = []prometheus.Collector{
+		alertsSentTotal,
+		alertsDroppedTotal,
+		alertsQueueLength,
+		sendLatencySeconds,
+		alertsRelabelDroppedTotal,
+	}
+
+	metricsMu           sync.Mutex
+	metricsRegisteredOn prometheus.Registerer
+)
+
+// RegisterMetrics registers this provider's Prometheus metrics against reg, defaulting to
+// prometheus.DefaultRegisterer when reg is nil. It's called from Start (and the lazy
+// dispatcherOrStart fallback) with a nil reg, so the metrics land on the default registerer
+// when the dispatcher starts rather than at package-init time the way promauto would; this
+// keeps the provider's metrics lifecycle consistent with the rest of the app's metrics,
+// which are registered/unregistered dynamically rather than eagerly.
+// Safe to call more than once; registration state is package-wide, so only the first call
+// takes effect until UnregisterMetrics is called.
+func RegisterMetrics(reg prometheus.Registerer) {
+	metricsMu.Lock()
+	defer metricsMu.Unlock()
+	if metricsRegisteredOn != nil {
+		return
+	}
+	if reg == nil {
+		reg = prometheus.DefaultRegisterer
+	}
+	for _, c := range metricsCollectors {
+		reg.MustRegister(c)
+	}
+	metricsRegisteredOn = reg
+}
+
+// UnregisterMetrics undoes RegisterMetrics, so a provider that's been stopped doesn't keep
+// reporting metrics for a dispatcher that's no longer running.
+func UnregisterMetrics() {
+	metricsMu.Lock()
+	defer metricsMu.Unlock()
+	if metricsRegisteredOn == nil {
+		return
+	}
+	for _, c := range metricsCollectors {
+		metricsRegisteredOn.Unregister(c)
+	}
+	metricsRegisteredOn = nil
+}
@@ -0,0 +1,124 @@
+package alertmanager
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// RelabelConfig describes a single relabeling rule applied to an alert's labels after the
+// base Gatus/Prometheus labels are assembled, mirroring Prometheus's alert relabeling.
+type RelabelConfig struct {
+	// SourceLabels is the list of label names whose values are joined with Separator to
+	// form the string matched against Regex. Used by the replace/keep/drop actions.
+	SourceLabels []string `yaml:"source_labels,omitempty"`
+
+	// Separator joins SourceLabels values together. Defaults to ";".
+	Separator string `yaml:"separator,omitempty"`
+
+	// Regex is matched, fully anchored, against the joined source label values (for
+	// replace/keep/drop) or against label names (for labelmap/labeldrop/labelkeep).
+	Regex string `yaml:"regex,omitempty"`
+
+	// TargetLabel is the label set to the expanded Replacement when action is replace.
+	TargetLabel string `yaml:"target_label,omitempty"`
+
+	// Replacement is expanded against the regex match (using $1, $2, ... capture groups)
+	// to produce the new label value (replace) or label name (labelmap).
+	Replacement string `yaml:"replacement,omitempty"`
+
+	// Action is one of replace, keep, drop, labelmap, labeldrop or labelkeep. Defaults to replace.
+	Action string `yaml:"action,omitempty"`
+
+	compileOnce   sync.Once
+	compiledRegex *regexp.Regexp
+	compileErr    error
+}
+
+// compile lazily compiles Regex, caching the result so it's only compiled once per config load.
+func (rc *RelabelConfig) compile() error {
+	rc.compileOnce.Do(func() {
+		pattern := rc.Regex
+		if len(pattern) == 0 {
+			pattern = "(.*)"
+		}
+		rc.compiledRegex, rc.compileErr = regexp.Compile("^(?:" + pattern + ")$")
+		if rc.compileErr != nil {
+			rc.compileErr = fmt.Errorf("invalid relabel regex %q: %w", rc.Regex, rc.compileErr)
+		}
+	})
+	return rc.compileErr
+}
+
+// applyRelabelConfigs runs labels through the relabel pipeline in order, mutating labels in
+// place. It returns false if a keep/drop rule dropped the alert entirely, in which case Send
+// must skip dispatch.
+func applyRelabelConfigs(labels map[string]string, configs []RelabelConfig) (bool, error) {
+	for i := range configs {
+		rc := &configs[i]
+		if err := rc.compile(); err != nil {
+			return true, err
+		}
+		separator := rc.Separator
+		if len(separator) == 0 {
+			separator = ";"
+		}
+		action := rc.Action
+		if len(action) == 0 {
+			action = "replace"
+		}
+		switch action {
+		case "labeldrop":
+			for name := range labels {
+				if rc.compiledRegex.MatchString(name) {
+					delete(labels, name)
+				}
+			}
+		case "labelkeep":
+			for name := range labels {
+				if !rc.compiledRegex.MatchString(name) {
+					delete(labels, name)
+				}
+			}
+		case "labelmap":
+			renamed := make(map[string]string)
+			for name, value := range labels {
+				if rc.compiledRegex.MatchString(name) {
+					renamed[rc.compiledRegex.ReplaceAllString(name, rc.Replacement)] = value
+				}
+			}
+			for name, value := range renamed {
+				labels[name] = value
+			}
+		case "keep", "drop":
+			if !matchesSourceLabels(labels, rc, separator) {
+				if action == "keep" {
+					return false, nil
+				}
+				continue
+			}
+			if action == "drop" {
+				return false, nil
+			}
+		default: // "replace"
+			value := joinSourceLabels(labels, rc.SourceLabels, separator)
+			if rc.compiledRegex.MatchString(value) && len(rc.TargetLabel) > 0 {
+				labels[rc.TargetLabel] = rc.compiledRegex.ReplaceAllString(value, rc.Replacement)
+			}
+		}
+	}
+	return true, nil
+}
+
+func joinSourceLabels(labels map[string]string, sourceLabels []string, separator string) string {
+	values := make([]string, 0, len(sourceLabels))
+	for _, name := range sourceLabels {
+		values = append(values, labels[name])
+	}
+	return strings.Join(values, separator)
+}
+
+func matchesSourceLabels(labels map[string]string, rc *RelabelConfig, separator string) bool {
+	return rc.compiledRegex.MatchString(joinSourceLabels(labels, rc.SourceLabels, separator))
+}
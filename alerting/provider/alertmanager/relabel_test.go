@@ -0,0 +1,128 @@
+package alertmanager
+
+import (
+	"testing"
+)
+
+func TestApplyRelabelConfigs_Replace(t *testing.T) {
+	labels := map[string]string{"group": "production"}
+	configs := []RelabelConfig{
+		{
+			SourceLabels: []string{"group"},
+			Regex:        "(.*)",
+			TargetLabel:  "namespace",
+			Replacement:  "$1",
+			Action:       "replace",
+		},
+	}
+
+	kept, err := applyRelabelConfigs(labels, configs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !kept {
+		t.Fatal("expected alert to be kept")
+	}
+	if labels["namespace"] != "production" {
+		t.Errorf("expected namespace to be 'production', got %s", labels["namespace"])
+	}
+}
+
+func TestApplyRelabelConfigs_Keep(t *testing.T) {
+	configs := []RelabelConfig{
+		{
+			SourceLabels: []string{"severity"},
+			Regex:        "critical",
+			Action:       "keep",
+		},
+	}
+
+	kept, err := applyRelabelConfigs(map[string]string{"severity": "critical"}, configs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !kept {
+		t.Error("expected matching alert to be kept")
+	}
+
+	kept, err = applyRelabelConfigs(map[string]string{"severity": "warning"}, configs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if kept {
+		t.Error("expected non-matching alert to be dropped")
+	}
+}
+
+func TestApplyRelabelConfigs_Drop(t *testing.T) {
+	configs := []RelabelConfig{
+		{
+			SourceLabels: []string{"group"},
+			Regex:        "maintenance",
+			Action:       "drop",
+		},
+	}
+
+	kept, err := applyRelabelConfigs(map[string]string{"group": "maintenance"}, configs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if kept {
+		t.Error("expected matching alert to be dropped")
+	}
+}
+
+func TestApplyRelabelConfigs_LabelmapLabeldropLabelkeep(t *testing.T) {
+	labels := map[string]string{"gatus_group": "production", "gatus_env": "prod", "instance": "api"}
+	configs := []RelabelConfig{
+		{
+			Regex:       "gatus_(.*)",
+			Replacement: "$1",
+			Action:      "labelmap",
+		},
+		{
+			Regex:  "gatus_.*",
+			Action: "labeldrop",
+		},
+	}
+
+	kept, err := applyRelabelConfigs(labels, configs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !kept {
+		t.Fatal("expected alert to be kept")
+	}
+	if labels["group"] != "production" || labels["env"] != "prod" {
+		t.Errorf("expected labelmap to produce group/env, got %+v", labels)
+	}
+	if _, ok := labels["gatus_group"]; ok {
+		t.Error("expected labeldrop to remove gatus_group")
+	}
+
+	labels = map[string]string{"instance": "api", "job": "gatus"}
+	configs = []RelabelConfig{
+		{
+			Regex:  "instance",
+			Action: "labelkeep",
+		},
+	}
+	if _, err := applyRelabelConfigs(labels, configs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := labels["job"]; ok {
+		t.Error("expected labelkeep to remove job")
+	}
+	if _, ok := labels["instance"]; !ok {
+		t.Error("expected labelkeep to preserve instance")
+	}
+}
+
+func TestApplyRelabelConfigs_InvalidRegex(t *testing.T) {
+	configs := []RelabelConfig{
+		{Regex: "(unclosed", Action: "replace"},
+	}
+	if _, err := applyRelabelConfigs(map[string]string{}, configs); err == nil {
+		t.Error("expected an error for an invalid regex")
+	}
+}
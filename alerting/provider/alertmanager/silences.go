@@ -0,0 +1,339 @@
+package alertmanager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/logr"
+)
+
+// silenceCreatedBy is written to the Alertmanager silence's createdBy field so that
+// reconcileSilences can later recognize and adopt silences this provider created,
+// including across restarts.
+const silenceCreatedBy = "gatus"
+
+// SilencesConfig opts an Alertmanager provider into automatically creating and expiring
+// Alertmanager silences for endpoint maintenance windows, instead of (or in addition to)
+// suppressing alerts client-side.
+type SilencesConfig struct {
+	// Enabled turns the feature on. Defaults to false.
+	Enabled bool `yaml:"enabled,omitempty"`
+
+	// MatcherLabels is the list of alert label names used to build the silence's matcher
+	// set. Defaults to []string{"endpoint"}. "group" is only included when the endpoint
+	// has a group.
+	MatcherLabels []string `yaml:"matcher_labels,omitempty"`
+}
+
+// MaintenanceWindow describes a single maintenance window an endpoint is entering or has
+// entered, as reported by the caller (e.g. the alerting controller) responsible for
+// evaluating maintenance schedules.
+type MaintenanceWindow struct {
+	Start   time.Time
+	End     time.Time
+	Comment string
+}
+
+// silenceKey identifies a maintenance window's silence, so restarts can reconcile against
+// Alertmanager's own silence list rather than creating a duplicate for a window already
+// silenced. start is stored in UTC so that times decoded from Alertmanager's JSON compare
+// equal to the caller's window start regardless of Location or monotonic clock reading.
+type silenceKey struct {
+	endpoint string
+	start    time.Time
+}
+
+// silenceManager tracks the Alertmanager silence ID created for each active maintenance
+// window, keyed by endpoint name and window start time.
+type silenceManager struct {
+	mu  sync.Mutex
+	ids map[silenceKey]string
+}
+
+func newSilenceManager() *silenceManager {
+	return &silenceManager{ids: make(map[silenceKey]string)}
+}
+
+type silenceMatcher struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	IsRegex bool   `json:"isRegex"`
+}
+
+type createSilenceRequest struct {
+	Matchers  []silenceMatcher `json:"matchers"`
+	StartsAt  time.Time        `json:"startsAt"`
+	EndsAt    time.Time        `json:"endsAt"`
+	CreatedBy string           `json:"createdBy"`
+	Comment   string           `json:"comment"`
+}
+
+type createSilenceResponse struct {
+	SilenceID string `json:"silenceID"`
+}
+
+type gettableSilence struct {
+	ID        string           `json:"id"`
+	Matchers  []silenceMatcher `json:"matchers"`
+	StartsAt  time.Time        `json:"startsAt"`
+	EndsAt    time.Time        `json:"endsAt"`
+	CreatedBy string           `json:"createdBy"`
+	Status    struct {
+		State string `json:"state"`
+	} `json:"status"`
+}
+
+// EnterMaintenance creates an Alertmanager silence matching ep for the given maintenance
+// window, unless a silence for this exact endpoint+window was already created (e.g. in a
+// prior reconcile). The created silence ID is tracked so ExitMaintenance can expire it
+// early if the window closes ahead of its configured end time.
+func (provider *AlertProvider) EnterMaintenance(ep *endpoint.Endpoint, window MaintenanceWindow) error {
+	cfg := &provider.DefaultConfig
+	if cfg.Silences == nil || !cfg.Silences.Enabled {
+		return nil
+	}
+	sm := provider.silenceManagerOrInit()
+	key := silenceKey{endpoint: ep.Name, start: window.Start.UTC()}
+	sm.mu.Lock()
+	_, exists := sm.ids[key]
+	sm.mu.Unlock()
+	if exists {
+		return nil
+	}
+	comment := window.Comment
+	if len(comment) == 0 {
+		comment = fmt.Sprintf("Gatus maintenance window for endpoint %s", ep.Name)
+	}
+	req := createSilenceRequest{
+		Matchers:  buildSilenceMatchers(cfg, ep),
+		StartsAt:  window.Start,
+		EndsAt:    window.End,
+		CreatedBy: silenceCreatedBy,
+		Comment:   comment,
+	}
+	id, err := provider.postSilence(cfg, req)
+	if err != nil {
+		return fmt.Errorf("failed to create silence for endpoint %s: %w", ep.Name, err)
+	}
+	sm.mu.Lock()
+	sm.ids[key] = id
+	sm.mu.Unlock()
+	return nil
+}
+
+// ExitMaintenance expires the Alertmanager silence tracked for ep's maintenance window
+// starting at windowStart. It does not check the window's scheduled end; any tracked
+// silence for that window is expired when this is called.
+func (provider *AlertProvider) ExitMaintenance(ep *endpoint.Endpoint, windowStart time.Time) error {
+	cfg := &provider.DefaultConfig
+	if cfg.Silences == nil || !cfg.Silences.Enabled {
+		return nil
+	}
+	sm := provider.silenceManagerOrInit()
+	key := silenceKey{endpoint: ep.Name, start: windowStart.UTC()}
+	sm.mu.Lock()
+	id, exists := sm.ids[key]
+	delete(sm.ids, key)
+	sm.mu.Unlock()
+	if !exists {
+		return nil
+	}
+	if err := provider.deleteSilence(cfg, id); err != nil {
+		return fmt.Errorf("failed to expire silence for endpoint %s: %w", ep.Name, err)
+	}
+	return nil
+}
+
+// ReconcileSilences fetches the silences Alertmanager currently knows about and adopts any
+// that this provider previously created (createdBy=gatus) and have not expired, so a
+// restart doesn't create duplicate silences for maintenance windows already in progress.
+func (provider *AlertProvider) ReconcileSilences() error {
+	cfg := &provider.DefaultConfig
+	if cfg.Silences == nil || !cfg.Silences.Enabled {
+		return nil
+	}
+	urls := provider.allTargets(cfg)
+	if len(urls) == 0 {
+		return ErrAlertmanagerURLNotSet
+	}
+	silences, err := provider.getSilences(cfg, urls[0])
+	if err != nil {
+		return fmt.Errorf("failed to list silences for reconciliation: %w", err)
+	}
+	sm := provider.silenceManagerOrInit()
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	for _, silence := range silences {
+		if silence.CreatedBy != silenceCreatedBy || silence.Status.State == "expired" {
+			continue
+		}
+		endpointName := matcherValue(silence.Matchers, "endpoint")
+		if len(endpointName) == 0 {
+			continue
+		}
+		sm.ids[silenceKey{endpoint: endpointName, start: silence.StartsAt.UTC()}] = silence.ID
+	}
+	return nil
+}
+
+func matcherValue(matchers []silenceMatcher, name string) string {
+	for _, m := range matchers {
+		if m.Name == name {
+			return m.Value
+		}
+	}
+	return ""
+}
+
+// buildSilenceMatchers builds the matcher set for ep from cfg.Silences.MatcherLabels,
+// defaulting to a single "endpoint" matcher.
+func buildSilenceMatchers(cfg *Config, ep *endpoint.Endpoint) []silenceMatcher {
+	labelNames := cfg.Silences.MatcherLabels
+	if len(labelNames) == 0 {
+		labelNames = []string{"endpoint"}
+	}
+	values := map[string]string{"endpoint": ep.Name}
+	if len(ep.Group) > 0 {
+		values["group"] = ep.Group
+	}
+	matchers := make([]silenceMatcher, 0, len(labelNames))
+	for _, name := range labelNames {
+		value, ok := values[name]
+		if !ok {
+			continue
+		}
+		matchers = append(matchers, silenceMatcher{Name: name, Value: value, IsRegex: false})
+	}
+	return matchers
+}
+
+func (provider *AlertProvider) silenceManagerOrInit() *silenceManager {
+	provider.mu.Lock()
+	defer provider.mu.Unlock()
+	if provider.silences == nil {
+		provider.silences = newSilenceManager()
+	}
+	return provider.silences
+}
+
+// requestContext returns a context bound by cfg.Timeout, or context.Background() unbounded
+// if cfg.Timeout is unset, matching the "0 means no timeout" semantics of http.Client.Timeout
+// that this replaces (see sendToReplica for why the timeout isn't applied to the client
+// directly).
+func requestContext(cfg *Config) (context.Context, context.CancelFunc) {
+	if cfg.Timeout <= 0 {
+		return context.Background(), func() {}
+	}
+	return context.WithTimeout(context.Background(), cfg.Timeout)
+}
+
+func (provider *AlertProvider) postSilence(cfg *Config, body createSilenceRequest) (string, error) {
+	urls := provider.allTargets(cfg)
+	if len(urls) == 0 {
+		return "", ErrAlertmanagerURLNotSet
+	}
+	jsonPayload, err := json.Marshal(body)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal silence: %w", err)
+	}
+	ctx, cancel := requestContext(cfg)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, silencesURL(urls[0], discoveryScheme(cfg)), bytes.NewReader(jsonPayload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	httpClient := client.GetHTTPClient(cfg.ClientConfig)
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("Alertmanager returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+	var created createSilenceResponse
+	if err := json.Unmarshal(respBody, &created); err != nil {
+		return "", fmt.Errorf("failed to decode silence response: %w", err)
+	}
+	return created.SilenceID, nil
+}
+
+func (provider *AlertProvider) deleteSilence(cfg *Config, id string) error {
+	urls := provider.allTargets(cfg)
+	if len(urls) == 0 {
+		return ErrAlertmanagerURLNotSet
+	}
+	ctx, cancel := requestContext(cfg)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, silenceURL(urls[0], discoveryScheme(cfg), id), nil)
+	if err != nil {
+		return err
+	}
+	httpClient := client.GetHTTPClient(cfg.ClientConfig)
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("Alertmanager returned status %d: %s", resp.StatusCode, string(body))
+	}
+	logr.Infof("[alertmanager] Expired silence %s", id)
+	return nil
+}
+
+func (provider *AlertProvider) getSilences(cfg *Config, url string) ([]gettableSilence, error) {
+	ctx, cancel := requestContext(cfg)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, silencesURL(url, discoveryScheme(cfg)), nil)
+	if err != nil {
+		return nil, err
+	}
+	httpClient := client.GetHTTPClient(cfg.ClientConfig)
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("Alertmanager returned status %d: %s", resp.StatusCode, string(body))
+	}
+	var silences []gettableSilence
+	if err := json.Unmarshal(body, &silences); err != nil {
+		return nil, fmt.Errorf("failed to decode silences response: %w", err)
+	}
+	return silences, nil
+}
+
+func silencesURL(base string, scheme string) string {
+	base = addSchemeIfMissing(base, scheme)
+	base = strings.TrimSuffix(base, "/")
+	base = strings.TrimSuffix(base, "/api/v2/alerts")
+	return base + "/api/v2/silences"
+}
+
+func silenceURL(base string, scheme string, id string) string {
+	base = addSchemeIfMissing(base, scheme)
+	base = strings.TrimSuffix(base, "/")
+	base = strings.TrimSuffix(base, "/api/v2/alerts")
+	return base + "/api/v2/silence/" + id
+}
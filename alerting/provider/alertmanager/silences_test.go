@@ -0,0 +1,165 @@
+package alertmanager
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+)
+
+func TestBuildSilenceMatchers(t *testing.T) {
+	cfg := &Config{Silences: &SilencesConfig{MatcherLabels: []string{"endpoint", "group"}}}
+	ep := &endpoint.Endpoint{Name: "Test API", Group: "production"}
+
+	matchers := buildSilenceMatchers(cfg, ep)
+	if len(matchers) != 2 {
+		t.Fatalf("expected 2 matchers, got %d: %+v", len(matchers), matchers)
+	}
+	if matchers[0].Name != "endpoint" || matchers[0].Value != "Test API" {
+		t.Errorf("unexpected endpoint matcher: %+v", matchers[0])
+	}
+	if matchers[1].Name != "group" || matchers[1].Value != "production" {
+		t.Errorf("unexpected group matcher: %+v", matchers[1])
+	}
+}
+
+func TestBuildSilenceMatchers_DefaultsToEndpoint(t *testing.T) {
+	cfg := &Config{Silences: &SilencesConfig{}}
+	ep := &endpoint.Endpoint{Name: "Test API"}
+
+	matchers := buildSilenceMatchers(cfg, ep)
+	if len(matchers) != 1 || matchers[0].Name != "endpoint" {
+		t.Errorf("expected a single endpoint matcher by default, got %+v", matchers)
+	}
+}
+
+func TestAlertProvider_EnterAndExitMaintenance(t *testing.T) {
+	var created createSilenceRequest
+	var deletedID string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v2/silences":
+			if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+				t.Errorf("failed to decode silence request: %v", err)
+			}
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(createSilenceResponse{SilenceID: "silence-1"})
+		case r.Method == http.MethodDelete:
+			deletedID = r.URL.Path[len("/api/v2/silence/"):]
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	provider := AlertProvider{
+		DefaultConfig: Config{
+			URL:      server.URL,
+			Silences: &SilencesConfig{Enabled: true},
+		},
+	}
+
+	ep := &endpoint.Endpoint{Name: "Test API", Group: "production"}
+	start := time.Now()
+	window := MaintenanceWindow{Start: start, End: start.Add(time.Hour), Comment: "scheduled maintenance"}
+
+	if err := provider.EnterMaintenance(ep, window); err != nil {
+		t.Fatalf("unexpected error entering maintenance: %v", err)
+	}
+	if len(created.Matchers) != 1 || created.Matchers[0].Value != "Test API" {
+		t.Errorf("unexpected matchers sent to Alertmanager: %+v", created.Matchers)
+	}
+	if created.CreatedBy != "gatus" {
+		t.Errorf("expected createdBy to be 'gatus', got %s", created.CreatedBy)
+	}
+
+	// Entering the same window again should not create a second silence.
+	if err := provider.EnterMaintenance(ep, window); err != nil {
+		t.Fatalf("unexpected error re-entering maintenance: %v", err)
+	}
+
+	if err := provider.ExitMaintenance(ep, start); err != nil {
+		t.Fatalf("unexpected error exiting maintenance: %v", err)
+	}
+	if deletedID != "silence-1" {
+		t.Errorf("expected silence-1 to be deleted, got %s", deletedID)
+	}
+}
+
+func TestAlertProvider_Maintenance_DisabledByDefault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("expected no request to Alertmanager when silences are disabled")
+	}))
+	defer server.Close()
+
+	provider := AlertProvider{DefaultConfig: Config{URL: server.URL}}
+	ep := &endpoint.Endpoint{Name: "Test API"}
+	window := MaintenanceWindow{Start: time.Now(), End: time.Now().Add(time.Hour)}
+
+	if err := provider.EnterMaintenance(ep, window); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := provider.ExitMaintenance(ep, window.Start); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestAlertProvider_ReconcileSilences(t *testing.T) {
+	start := time.Now().Truncate(time.Second)
+	var deletedID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v2/silences":
+			silences := []gettableSilence{
+				{
+					ID:        "existing-silence",
+					Matchers:  []silenceMatcher{{Name: "endpoint", Value: "Test API"}},
+					StartsAt:  start,
+					EndsAt:    start.Add(time.Hour),
+					CreatedBy: "gatus",
+				},
+				{
+					ID:        "unrelated-silence",
+					Matchers:  []silenceMatcher{{Name: "endpoint", Value: "Other"}},
+					StartsAt:  start,
+					CreatedBy: "someone-else",
+				},
+			}
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(silences)
+		case r.Method == http.MethodDelete:
+			deletedID = r.URL.Path[len("/api/v2/silence/"):]
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	provider := AlertProvider{
+		DefaultConfig: Config{
+			URL:      server.URL,
+			Silences: &SilencesConfig{Enabled: true},
+		},
+	}
+
+	if err := provider.ReconcileSilences(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ep := &endpoint.Endpoint{Name: "Test API"}
+	// Exiting the adopted window should expire the reconciled silence, proving it was
+	// tracked without a second EnterMaintenance call.
+	if err := provider.ExitMaintenance(ep, start); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if deletedID != "existing-silence" {
+		t.Errorf("expected reconciled silence existing-silence to be expired, got %q", deletedID)
+	}
+}
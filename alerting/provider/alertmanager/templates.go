@@ -0,0 +1,159 @@
+package alertmanager
+
+import (
+	"bytes"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/logr"
+)
+
+// bodySnippetMaxLength caps how much of Result.Body is exposed to templates, so a large
+// response body doesn't blow up a rendered label or annotation.
+const bodySnippetMaxLength = 500
+
+// defaultGeneratorURLTemplate points generatorURL at Gatus's own status API for the
+// endpoint when Config.GeneratorURL isn't set, so Alertmanager's "Source" link leads
+// operators back to the endpoint that fired the alert.
+const defaultGeneratorURLTemplate = "/api/v1/endpoints/{{ .Endpoint.Key }}/statuses"
+
+// templateData is the context exposed to ExtraLabels, ExtraAnnotations, GeneratorURL and
+// AlertName templates.
+type templateData struct {
+	Endpoint templateEndpointData
+	Alert    templateAlertData
+	Result   templateResultData
+	Resolved bool
+}
+
+type templateEndpointData struct {
+	Name       string
+	URL        string
+	Group      string
+	Key        string
+	Conditions []string
+}
+
+type templateAlertData struct {
+	Description string
+	Threshold   int
+	Type        string
+}
+
+type templateResultData struct {
+	Errors           []string
+	HTTPStatus       int
+	Duration         time.Duration
+	ConditionResults []templateConditionResultData
+	BodySnippet      string
+}
+
+type templateConditionResultData struct {
+	Condition string
+	Success   bool
+}
+
+// buildTemplateData assembles the rendering context for a single alert from the endpoint,
+// alert and result that triggered it.
+func buildTemplateData(ep *endpoint.Endpoint, a *alert.Alert, result *endpoint.Result, resolved bool) templateData {
+	conditions := make([]string, 0, len(ep.Conditions))
+	for _, condition := range ep.Conditions {
+		conditions = append(conditions, string(condition))
+	}
+	threshold := a.FailureThreshold
+	if resolved {
+		threshold = a.SuccessThreshold
+	}
+	conditionResults := make([]templateConditionResultData, 0, len(result.ConditionResults))
+	for _, conditionResult := range result.ConditionResults {
+		conditionResults = append(conditionResults, templateConditionResultData{
+			Condition: conditionResult.Condition,
+			Success:   conditionResult.Success,
+		})
+	}
+	bodySnippet := string(result.Body)
+	if len(bodySnippet) > bodySnippetMaxLength {
+		bodySnippet = bodySnippet[:bodySnippetMaxLength]
+	}
+	return templateData{
+		Endpoint: templateEndpointData{
+			Name:       ep.Name,
+			URL:        ep.URL,
+			Group:      ep.Group,
+			Key:        ep.Key(),
+			Conditions: conditions,
+		},
+		Alert: templateAlertData{
+			Description: a.GetDescription(),
+			Threshold:   threshold,
+			Type:        string(a.Type),
+		},
+		Result: templateResultData{
+			Errors:           result.Errors,
+			HTTPStatus:       result.HTTPStatus,
+			Duration:         result.Duration,
+			ConditionResults: conditionResults,
+			BodySnippet:      bodySnippet,
+		},
+		Resolved: resolved,
+	}
+}
+
+// templateCache caches parsed *template.Template by raw template source, so identical
+// ExtraLabels/ExtraAnnotations/GeneratorURL/AlertName values configured across overrides or
+// repeated Send calls are only ever parsed once.
+var templateCache sync.Map // map[string]*compiledTemplate
+
+// compiledTemplate lazily parses its raw source exactly once and caches the result.
+type compiledTemplate struct {
+	raw      string
+	compile  sync.Once
+	template *template.Template
+	err      error
+}
+
+func getCompiledTemplate(raw string) *compiledTemplate {
+	if v, ok := templateCache.Load(raw); ok {
+		return v.(*compiledTemplate)
+	}
+	actual, _ := templateCache.LoadOrStore(raw, &compiledTemplate{raw: raw})
+	return actual.(*compiledTemplate)
+}
+
+func (ct *compiledTemplate) parse() (*template.Template, error) {
+	ct.compile.Do(func() {
+		ct.template, ct.err = template.New("").Parse(ct.raw)
+	})
+	return ct.template, ct.err
+}
+
+// render renders raw as a Go template against data, falling back to the literal raw string
+// (with a logged warning) if the template fails to parse or execute.
+func render(raw string, data templateData) string {
+	if len(raw) == 0 {
+		return raw
+	}
+	tmpl, err := getCompiledTemplate(raw).parse()
+	if err != nil {
+		logr.Warnf("[alertmanager] Failed to parse template %q, using literal value: %s", raw, err)
+		return raw
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		logr.Warnf("[alertmanager] Failed to render template %q, using literal value: %s", raw, err)
+		return raw
+	}
+	return buf.String()
+}
+
+// renderGeneratorURL renders cfg.GeneratorURL, or defaultGeneratorURLTemplate if unset.
+func renderGeneratorURL(cfg *Config, data templateData) string {
+	raw := cfg.GeneratorURL
+	if len(raw) == 0 {
+		raw = defaultGeneratorURLTemplate
+	}
+	return render(raw, data)
+}